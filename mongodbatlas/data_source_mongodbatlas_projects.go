@@ -0,0 +1,301 @@
+package mongodbatlas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	matlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	errorProjectsList    = "error getting projects: %s"
+	errorProjectsSetting = "error setting `%s` for projects: %s"
+)
+
+func dataSourceMongoDBAtlasProjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMongoDBAtlasProjectsRead,
+		Schema: map[string]*schema.Schema{
+			// page_num pins `results`/`total_count` to a single Atlas page. Leave it unset to
+			// have this data source walk every page itself, so `name_regex`/`org_id` filtering
+			// and `for_each` see the whole org.
+			"page_num": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"items_per_page": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"org_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			// total_count is the number of projects in `results` once `name_regex`/`org_id` are
+			// applied. When `page_num` is set, it instead reports Atlas's own org-wide total,
+			// since `results` in that case only covers the requested page.
+			"total_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"org_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"teams": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"team_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"role_names": {
+										Type:     schema.TypeSet,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+						"api_keys": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"api_key_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"role_names": {
+										Type:     schema.TypeSet,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+						"is_collect_database_specifics_statistics_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_data_explorer_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_performance_advisor_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_realtime_performance_panel_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_schema_advisor_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// defaultProjectsItemsPerPage is the page size used when auto-paginating through every project,
+// i.e. whenever the caller doesn't pin a specific `page_num`.
+const defaultProjectsItemsPerPage = 500
+
+// fetchAllProjectPages walks every page fetchPage returns, stopping once a page comes back short
+// of itemsPerPage (the last page) or the running total reaches Atlas's own TotalCount, whichever
+// happens first. It's factored out of dataSourceMongoDBAtlasProjectsRead so the termination
+// condition can be unit-tested without a live Atlas connection.
+func fetchAllProjectPages(itemsPerPage int, fetchPage func(page, itemsPerPage int) (*matlas.Projects, error)) ([]*matlas.Project, error) {
+	var allProjects []*matlas.Project
+
+	for page := 1; ; page++ {
+		projects, err := fetchPage(page, itemsPerPage)
+		if err != nil {
+			return nil, err
+		}
+
+		allProjects = append(allProjects, projects.Results...)
+
+		if len(projects.Results) < itemsPerPage || len(allProjects) >= projects.TotalCount {
+			break
+		}
+	}
+
+	return allProjects, nil
+}
+
+func dataSourceMongoDBAtlasProjectsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*MongoDBClient).Atlas
+
+	orgID, hasOrgID := d.GetOk("org_id")
+
+	var nameMatcher *regexp.Regexp
+
+	if nameRegex, ok := d.GetOk("name_regex"); ok {
+		var err error
+
+		nameMatcher, err = regexp.Compile(nameRegex.(string))
+		if err != nil {
+			return diag.Errorf("error compiling `name_regex`: %s", err)
+		}
+	}
+
+	var allProjects []*matlas.Project
+
+	// explicitPage reports whether the caller pinned a specific page, in which case `total_count`
+	// reports Atlas's own org-wide totalCount rather than a count scoped to the filtered results,
+	// since `results` itself only covers that one page.
+	_, explicitPage := d.GetOk("page_num")
+
+	var apiTotalCount int
+
+	if explicitPage {
+		projects, _, err := conn.Projects.GetAllProjects(ctx, &matlas.ListOptions{
+			PageNum:      d.Get("page_num").(int),
+			ItemsPerPage: d.Get("items_per_page").(int),
+		})
+		if err != nil {
+			return diag.Errorf(errorProjectsList, err)
+		}
+
+		allProjects = projects.Results
+		apiTotalCount = projects.TotalCount
+	} else {
+		// Otherwise walk every page server-side so `name_regex`/`org_id` filtering, and
+		// `for_each` over `results`, see every project in the org rather than just page one.
+		itemsPerPage := d.Get("items_per_page").(int)
+		if itemsPerPage <= 0 {
+			itemsPerPage = defaultProjectsItemsPerPage
+		}
+
+		var err error
+
+		allProjects, err = fetchAllProjectPages(itemsPerPage, func(page, itemsPerPage int) (*matlas.Projects, error) {
+			projects, _, err := conn.Projects.GetAllProjects(ctx, &matlas.ListOptions{
+				PageNum:      page,
+				ItemsPerPage: itemsPerPage,
+			})
+
+			return projects, err
+		})
+		if err != nil {
+			return diag.Errorf(errorProjectsList, err)
+		}
+	}
+
+	results := make([]map[string]interface{}, 0, len(allProjects))
+
+	for _, project := range allProjects {
+		if hasOrgID && project.OrgID != orgID.(string) {
+			continue
+		}
+
+		if nameMatcher != nil && !nameMatcher.MatchString(project.Name) {
+			continue
+		}
+
+		result, err := flattenProjectsResult(ctx, conn, project)
+		if err != nil {
+			return diag.Errorf(errorProjectsList, err)
+		}
+
+		results = append(results, result)
+	}
+
+	if err := d.Set("results", results); err != nil {
+		return diag.Errorf(errorProjectsSetting, "results", err)
+	}
+
+	totalCount := len(results)
+	if explicitPage {
+		totalCount = apiTotalCount
+	}
+
+	if err := d.Set("total_count", totalCount); err != nil {
+		return diag.Errorf(errorProjectsSetting, "total_count", err)
+	}
+
+	d.SetId(resource.UniqueId())
+
+	return nil
+}
+
+// flattenProjectsResult fetches and flattens the same sub-resources resourceMongoDBAtlasProjectRead
+// does, so `mongodbatlas_projects.results` mirrors the `mongodbatlas_project` resource schema.
+func flattenProjectsResult(ctx context.Context, conn *matlas.Client, project *matlas.Project) (map[string]interface{}, error) {
+	teams, _, err := conn.Projects.GetProjectTeamsAssigned(ctx, project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting project's teams assigned (%s): %w", project.ID, err)
+	}
+
+	apiKeys, err := getProjectAPIKeys(ctx, conn, project.OrgID, project.ID)
+	if err != nil {
+		var target *matlas.ErrorResponse
+		if errors.As(err, &target) && target.ErrorCode != "USER_UNAUTHORIZED" {
+			return nil, fmt.Errorf("error getting project's api keys (%s): %w", project.ID, err)
+		}
+
+		log.Printf("[WARN] `api_keys` will be empty for project (%s) because the caller has no permission to read the api keys endpoint", project.ID)
+
+		apiKeys = nil
+	}
+
+	settings, _, err := conn.Projects.GetProjectSettings(ctx, project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting project's settings (%s): %w", project.ID, err)
+	}
+
+	return map[string]interface{}{
+		"id":            project.ID,
+		"org_id":        project.OrgID,
+		"name":          project.Name,
+		"cluster_count": project.ClusterCount,
+		"created":       project.Created,
+		"teams":         flattenTeams(teams),
+		"api_keys":      flattenAPIKeys(apiKeys),
+		"is_collect_database_specifics_statistics_enabled": settings.IsCollectDatabaseSpecificsStatisticsEnabled,
+		"is_data_explorer_enabled":                         settings.IsDataExplorerEnabled,
+		"is_performance_advisor_enabled":                   settings.IsPerformanceAdvisorEnabled,
+		"is_realtime_performance_panel_enabled":            settings.IsRealtimePerformancePanelEnabled,
+		"is_schema_advisor_enabled":                        settings.IsSchemaAdvisorEnabled,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package mongodbatlas
+
+import (
+	"errors"
+	"testing"
+
+	matlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+func newProject(id string) *matlas.Project {
+	return &matlas.Project{ID: id}
+}
+
+func TestFetchAllProjectPages(t *testing.T) {
+	tests := map[string]struct {
+		itemsPerPage int
+		pages        [][]*matlas.Project
+		totalCount   int
+		wantIDs      []string
+	}{
+		"single short page stops immediately": {
+			itemsPerPage: 2,
+			pages:        [][]*matlas.Project{{newProject("1")}},
+			totalCount:   1,
+			wantIDs:      []string{"1"},
+		},
+		"full page followed by a short page stops at the short page": {
+			itemsPerPage: 2,
+			pages: [][]*matlas.Project{
+				{newProject("1"), newProject("2")},
+				{newProject("3")},
+			},
+			totalCount: 3,
+			wantIDs:    []string{"1", "2", "3"},
+		},
+		"running total reaching TotalCount stops even on a full page": {
+			itemsPerPage: 2,
+			pages: [][]*matlas.Project{
+				{newProject("1"), newProject("2")},
+			},
+			totalCount: 2,
+			wantIDs:    []string{"1", "2"},
+		},
+		"empty result set stops on the first, empty page": {
+			itemsPerPage: 2,
+			pages:        [][]*matlas.Project{{}},
+			totalCount:   0,
+			wantIDs:      nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+
+			got, err := fetchAllProjectPages(tc.itemsPerPage, func(page, itemsPerPage int) (*matlas.Projects, error) {
+				calls++
+				if page > len(tc.pages) {
+					t.Fatalf("fetchPage called for page %d beyond the %d pages this test provides", page, len(tc.pages))
+				}
+
+				return &matlas.Projects{Results: tc.pages[page-1], TotalCount: tc.totalCount}, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if calls != len(tc.pages) {
+				t.Fatalf("fetchPage called %d times, want %d (loop didn't stop at the right page)", calls, len(tc.pages))
+			}
+
+			gotIDs := make([]string, len(got))
+			for i, p := range got {
+				gotIDs[i] = p.ID
+			}
+
+			assertStringSliceEqual(t, "ids", gotIDs, tc.wantIDs)
+		})
+	}
+}
+
+func TestFetchAllProjectPagesPropagatesError(t *testing.T) {
+	wantErr := errors.New("boom")
+
+	_, err := fetchAllProjectPages(2, func(page, itemsPerPage int) (*matlas.Projects, error) {
+		return nil, wantErr
+	})
+
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("got error %v, want %v", err, wantErr)
+	}
+}
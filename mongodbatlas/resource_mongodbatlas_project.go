@@ -3,22 +3,39 @@ package mongodbatlas
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/openlyinc/pointy"
 	matlas "go.mongodb.org/atlas/mongodbatlas"
 )
 
+// validProjectRoleNames are the project-scoped roles Atlas accepts for `teams.role_names` and
+// `api_keys.role_names`. Catching an invalid value here turns an opaque 400 at apply time into a
+// plan-time validation error.
+var validProjectRoleNames = []string{
+	"GROUP_OWNER",
+	"GROUP_CLUSTER_MANAGER",
+	"GROUP_READ_ONLY",
+	"GROUP_DATA_ACCESS_ADMIN",
+	"GROUP_DATA_ACCESS_READ_WRITE",
+	"GROUP_DATA_ACCESS_READ_ONLY",
+	"GROUP_SEARCH_INDEX_EDITOR",
+}
+
 const (
-	errorProjectCreate  = "error creating Project: %s"
-	errorProjectRead    = "error getting project(%s): %s"
-	errorProjectDelete  = "error deleting project (%s): %s"
-	errorProjectSetting = "error setting `%s` for project (%s): %s"
+	errorProjectCreate           = "error creating Project: %s"
+	errorProjectRead             = "error getting project(%s): %s"
+	errorProjectDelete           = "error deleting project (%s): %s"
+	errorProjectDeleteDependents = "error deleting project (%s): %s (dependents still present: %s)"
+	errorProjectSetting          = "error setting `%s` for project (%s): %s"
 )
 
 func resourceMongoDBAtlasProject() *schema.Resource {
@@ -30,10 +47,18 @@ func resourceMongoDBAtlasProject() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceMongoDBAtlasProjectCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
+				// ForceNew WONTFIX (ericlutley/terraform-provider-mongodbatlas#chunk0-1): that
+				// request asked for in-place renames via ProjectsService.Update, but the pinned
+				// go.mongodb.org/atlas@v0.16.1-0.20220531163122-551edbfb2f27 has no such method
+				// or ProjectUpdateRequest type, so a name change can only be applied as a
+				// destroy/recreate. Re-open chunk0-1 once the SDK is bumped to a version that
+				// exposes project renames; until then this is a deliberate won't-fix, not an
+				// oversight.
 				ForceNew: true,
 			},
 			"org_id": {
@@ -62,7 +87,8 @@ func resourceMongoDBAtlasProject() *schema.Resource {
 							Type:     schema.TypeSet,
 							Required: true,
 							Elem: &schema.Schema{
-								Type: schema.TypeString,
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(validProjectRoleNames, false),
 							},
 						},
 					},
@@ -91,7 +117,8 @@ func resourceMongoDBAtlasProject() *schema.Resource {
 							Type:     schema.TypeSet,
 							Required: true,
 							Elem: &schema.Schema{
-								Type: schema.TypeString,
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(validProjectRoleNames, false),
 							},
 						},
 					},
@@ -122,13 +149,98 @@ func resourceMongoDBAtlasProject() *schema.Resource {
 				Computed: true,
 				Optional: true,
 			},
+			"deletion_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
 
-// Resources that need to be cleaned up before a project can be deleted
+// AtlastProjectDependents is the aggregate result of every dependentChecker, i.e. the
+// resources that need to be cleaned up before a project can be deleted.
 type AtlastProjectDependents struct {
-	AdvancedClusters *matlas.AdvancedClustersResponse
+	Statuses []dependentStatus
+}
+
+// dependentStatus is what one dependentChecker found for a single category of dependent resource.
+type dependentStatus struct {
+	name     string // e.g. "advanced_clusters", used in diagnostics
+	present  bool   // at least one resource of this type still exists in the project
+	deleting bool   // every resource found is in a terminal deleting state
+}
+
+// names lists the dependent types that still have at least one resource present,
+// regardless of whether that resource is in the process of being deleted.
+func (d *AtlastProjectDependents) names() []string {
+	names := make([]string, 0, len(d.Statuses))
+
+	for _, status := range d.Statuses {
+		if status.present {
+			names = append(names, status.name)
+		}
+	}
+
+	return names
+}
+
+// readyToDelete reports whether it's worth continuing to poll before attempting Projects.Delete:
+// every present dependent must be in a terminal deleting state, and at least one must be present,
+// otherwise there's nothing left to wait on.
+func (d *AtlastProjectDependents) readyToDelete() bool {
+	anyDeleting := false
+
+	for _, status := range d.Statuses {
+		if status.present && !status.deleting {
+			return false
+		}
+
+		if status.present && status.deleting {
+			anyDeleting = true
+		}
+	}
+
+	return anyDeleting
+}
+
+// dependentChecker inspects one category of Atlas resources that can block project deletion.
+// Register new dependent types by adding a checker to projectDependentCheckers.
+type dependentChecker func(ctx context.Context, projectID string, client *matlas.Client) (dependentStatus, error)
+
+// projectDependentCheckers holds every dependentChecker except advanced_clusters/online_archives:
+// those two both need the same AdvancedClusters.List call, so resourceProjectDependents fetches it
+// once and derives both statuses from it instead of registering them here.
+var projectDependentCheckers = []dependentChecker{
+	checkServerlessInstancesDependents,
+	checkDataLakesDependents,
+	checkThirdPartyIntegrationsDependents,
+}
+
+// resourceMongoDBAtlasProjectCustomizeDiff rejects a `project_owner_id` that doesn't reference an
+// existing Atlas user at plan time, rather than letting Projects.Create fail at apply time. It only
+// checks that AtlasUsers.Get finds the user; whether that user belongs to `org_id` is not checked.
+func resourceMongoDBAtlasProjectCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// project_owner_id only has any effect on Create; Atlas has no field to read it back into and
+	// this resource never changes it post-creation. Validating it on every plan of an existing,
+	// untouched project would turn routine refreshes into a live API call that can fail if the
+	// user was later removed from the org.
+	if d.Id() != "" && !d.HasChange("project_owner_id") {
+		return nil
+	}
+
+	projectOwnerID, ok := d.GetOk("project_owner_id")
+	if !ok {
+		return nil
+	}
+
+	conn := meta.(*MongoDBClient).Atlas
+
+	if _, _, err := conn.AtlasUsers.Get(ctx, projectOwnerID.(string)); err != nil {
+		return fmt.Errorf("project_owner_id %q does not reference an existing Atlas user: %w", projectOwnerID, err)
+	}
+
+	return nil
 }
 
 func resourceMongoDBAtlasProjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -278,81 +390,18 @@ func resourceMongoDBAtlasProjectUpdate(ctx context.Context, d *schema.ResourceDa
 	conn := meta.(*MongoDBClient).Atlas
 	projectID := d.Id()
 
-	if d.HasChange("teams") {
-		// get the current teams and the new teams with changes
-		newTeams, changedTeams, removedTeams := getStateTeams(d)
-
-		// adding new teams into the project
-		if len(newTeams) > 0 {
-			_, _, err := conn.Projects.AddTeamsToProject(ctx, projectID, expandTeamsList(newTeams))
-			if err != nil {
-				return diag.Errorf("error adding teams into the project(%s): %s", projectID, err)
-			}
-		}
-
-		// Removing teams from the project
-		for _, team := range removedTeams {
-			teamID := team.(map[string]interface{})["team_id"].(string)
-
-			_, err := conn.Teams.RemoveTeamFromProject(ctx, projectID, teamID)
-			if err != nil {
-				var target *matlas.ErrorResponse
-				if errors.As(err, &target) && target.ErrorCode != "USER_UNAUTHORIZED" {
-					return diag.Errorf("error removing team(%s) from the project(%s): %s", teamID, projectID, err)
-				}
-				log.Printf("[WARN] error removing team(%s) from the project(%s): %s", teamID, projectID, err)
-			}
-		}
-
-		// Updating the role names for a team
-		for _, t := range changedTeams {
-			team := t.(map[string]interface{})
+	var diags diag.Diagnostics
 
-			_, _, err := conn.Teams.UpdateTeamRoles(ctx, projectID, team["team_id"].(string),
-				&matlas.TeamUpdateRoles{
-					RoleNames: expandStringList(team["role_names"].(*schema.Set).List()),
-				},
-			)
-			if err != nil {
-				return diag.Errorf("error updating role names for the team(%s): %s", team["team_id"], err)
-			}
-		}
+	if d.HasChange("teams") {
+		diags = append(diags, syncProjectTeams(ctx, conn, projectID, d)...)
 	}
 
 	if d.HasChange("api_keys") {
-		// get the current api_keys and the new api_keys with changes
-		newAPIKeys, changedAPIKeys, removedAPIKeys := getStateAPIKeys(d)
-
-		// adding new api_keys into the project
-		if len(newAPIKeys) > 0 {
-			for _, apiKey := range expandAPIKeysList(newAPIKeys) {
-				_, err := conn.ProjectAPIKeys.Assign(ctx, projectID, apiKey.id, &matlas.AssignAPIKey{
-					Roles: apiKey.roles,
-				})
-				if err != nil {
-					return diag.Errorf("error assigning api_keys into the project(%s): %s", projectID, err)
-				}
-			}
-		}
-
-		// Removing api_keys from the project
-		for _, apiKey := range removedAPIKeys {
-			apiKeyID := apiKey.(map[string]interface{})["api_key_id"].(string)
-			_, err := conn.ProjectAPIKeys.Unassign(ctx, projectID, apiKeyID)
-			if err != nil {
-				return diag.Errorf("error removing api_key(%s) from the project(%s): %s", apiKeyID, projectID, err)
-			}
-		}
+		diags = append(diags, syncProjectAPIKeys(ctx, conn, projectID, d)...)
+	}
 
-		// Updating the role names for the api_key
-		for _, apiKey := range expandAPIKeysList(changedAPIKeys) {
-			_, err := conn.ProjectAPIKeys.Assign(ctx, projectID, apiKey.id, &matlas.AssignAPIKey{
-				Roles: apiKey.roles,
-			})
-			if err != nil {
-				return diag.Errorf("error updating role names for the api_key(%s): %s", apiKey, err)
-			}
-		}
+	if diags.HasError() {
+		return diags
 	}
 
 	projectSettings, _, err := conn.Projects.GetProjectSettings(ctx, projectID)
@@ -391,6 +440,17 @@ func resourceMongoDBAtlasProjectDelete(ctx context.Context, d *schema.ResourceDa
 	conn := meta.(*MongoDBClient).Atlas
 	projectID := d.Id()
 
+	if d.Get("deletion_protection").(bool) {
+		dependents, err := resourceProjectDependents(ctx, projectID, conn)
+		if err != nil {
+			return diag.Errorf("error checking dependents for project (%s): %s", projectID, err)
+		}
+
+		if names := dependents.names(); len(names) > 0 {
+			return diag.Errorf("project (%s) has `deletion_protection` enabled and still has dependents: %s", projectID, strings.Join(names, ", "))
+		}
+	}
+
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"DELETING", "RETRY"},
 		Target:     []string{"IDLE"},
@@ -409,6 +469,12 @@ func resourceMongoDBAtlasProjectDelete(ctx context.Context, d *schema.ResourceDa
 	_, err = conn.Projects.Delete(ctx, projectID)
 
 	if err != nil {
+		if dependents, depErr := resourceProjectDependents(ctx, projectID, conn); depErr == nil {
+			if names := dependents.names(); len(names) > 0 {
+				return diag.Errorf(errorProjectDeleteDependents, projectID, err, strings.Join(names, ", "))
+			}
+		}
+
 		return diag.Errorf(errorProjectDelete, projectID, err)
 	}
 
@@ -427,8 +493,7 @@ func resourceMongoDBAtlasProjectDelete(ctx context.Context, d *schema.ResourceDa
 func resourceProjectDependentsDeletingRefreshFunc(ctx context.Context, projectID string, client *matlas.Client) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		var target *matlas.ErrorResponse
-		clusters, _, err := client.AdvancedClusters.List(ctx, projectID, nil)
-		dependents := AtlastProjectDependents{AdvancedClusters: clusters}
+		dependents, err := resourceProjectDependents(ctx, projectID, client)
 
 		if errors.As(err, &target) {
 			return nil, "", err
@@ -436,54 +501,128 @@ func resourceProjectDependentsDeletingRefreshFunc(ctx context.Context, projectID
 			return nil, "RETRY", nil
 		}
 
-		if dependents.AdvancedClusters.TotalCount == 0 {
-			return dependents, "IDLE", nil
+		if dependents.readyToDelete() {
+			log.Printf("[DEBUG] status for MongoDB project %s dependents: %s", projectID, "DELETING")
+			return dependents, "DELETING", nil
 		}
 
-		for _, v := range dependents.AdvancedClusters.Results {
-			if v.StateName != "DELETING" {
-				return dependents, "IDLE", nil
-			}
+		return dependents, "IDLE", nil
+	}
+}
+
+// resourceProjectDependents runs every registered dependentChecker plus the advanced_clusters/
+// online_archives pair (sharing one AdvancedClusters.List call between them) and aggregates the
+// results. A checker's error aborts the whole check, matching the single-call behavior this replaced.
+func resourceProjectDependents(ctx context.Context, projectID string, client *matlas.Client) (*AtlastProjectDependents, error) {
+	clusters, _, err := client.AdvancedClusters.List(ctx, projectID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]dependentStatus, 0, len(projectDependentCheckers)+2)
+	statuses = append(statuses, advancedClustersDependentsStatus(clusters))
+
+	onlineArchivesStatus, err := onlineArchivesDependentsStatus(ctx, projectID, client, clusters)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses = append(statuses, onlineArchivesStatus)
+
+	for _, check := range projectDependentCheckers {
+		status, err := check(ctx, projectID, client)
+		if err != nil {
+			return nil, err
 		}
 
-		log.Printf("[DEBUG] status for MongoDB project %s dependents: %s", projectID, "DELETING")
+		statuses = append(statuses, status)
+	}
 
-		return dependents, "DELETING", nil
+	return &AtlastProjectDependents{Statuses: statuses}, nil
+}
+
+// advancedClustersDependentsStatus derives the advanced_clusters dependentStatus from a cluster
+// list resourceProjectDependents already fetched for onlineArchivesDependentsStatus, instead of
+// issuing a second AdvancedClusters.List call.
+func advancedClustersDependentsStatus(clusters *matlas.AdvancedClustersResponse) dependentStatus {
+	status := dependentStatus{name: "advanced_clusters", present: clusters.TotalCount > 0, deleting: true}
+
+	for _, v := range clusters.Results {
+		if v.StateName != "DELETING" {
+			status.deleting = false
+			break
+		}
 	}
+
+	return status
 }
 
-func expandTeamsSet(teams *schema.Set) []*matlas.ProjectTeam {
-	res := make([]*matlas.ProjectTeam, teams.Len())
+func checkServerlessInstancesDependents(ctx context.Context, projectID string, client *matlas.Client) (dependentStatus, error) {
+	instances, _, err := client.ServerlessInstances.List(ctx, projectID, nil)
+	if err != nil {
+		return dependentStatus{}, err
+	}
 
-	for i, value := range teams.List() {
-		v := value.(map[string]interface{})
-		res[i] = &matlas.ProjectTeam{
-			TeamID:    v["team_id"].(string),
-			RoleNames: expandStringList(v["role_names"].(*schema.Set).List()),
+	status := dependentStatus{name: "serverless_instances", present: instances.TotalCount > 0, deleting: true}
+
+	for _, v := range instances.Results {
+		if v.StateName != "DELETING" {
+			status.deleting = false
+			break
 		}
 	}
 
-	return res
+	return status, nil
 }
 
-func expandAPIKeysSet(apiKeys *schema.Set) []*apiKey {
-	res := make([]*apiKey, apiKeys.Len())
+func checkDataLakesDependents(ctx context.Context, projectID string, client *matlas.Client) (dependentStatus, error) {
+	dataLakes, _, err := client.DataLakes.List(ctx, projectID)
+	if err != nil {
+		return dependentStatus{}, err
+	}
 
-	for i, value := range apiKeys.List() {
-		v := value.(map[string]interface{})
-		res[i] = &apiKey{
-			id:    v["api_key_id"].(string),
-			roles: expandStringList(v["role_names"].(*schema.Set).List()),
+	// Data Lakes (the pinned SDK predates the "Data Federation" rename) are deleted
+	// synchronously, so any that remain block deletion outright.
+	return dependentStatus{name: "data_lakes", present: len(dataLakes) > 0, deleting: false}, nil
+}
+
+// onlineArchivesDependentsStatus checks OnlineArchives per cluster, reusing the cluster list
+// advancedClustersDependentsStatus already derived from instead of refetching it.
+func onlineArchivesDependentsStatus(ctx context.Context, projectID string, client *matlas.Client, clusters *matlas.AdvancedClustersResponse) (dependentStatus, error) {
+	status := dependentStatus{name: "online_archives", deleting: true}
+
+	for _, cluster := range clusters.Results {
+		archives, _, err := client.OnlineArchives.List(ctx, projectID, cluster.Name, nil)
+		if err != nil {
+			return dependentStatus{}, err
+		}
+
+		for _, archive := range archives.Results {
+			status.present = true
+
+			if archive.State != "DELETING" {
+				status.deleting = false
+			}
 		}
 	}
 
-	return res
+	return status, nil
+}
+
+func checkThirdPartyIntegrationsDependents(ctx context.Context, projectID string, client *matlas.Client) (dependentStatus, error) {
+	integrations, _, err := client.Integrations.List(ctx, projectID)
+	if err != nil {
+		return dependentStatus{}, err
+	}
+
+	// Third-party integrations have no deleting lifecycle of their own; any that remain block deletion.
+	return dependentStatus{name: "third_party_integrations", present: len(integrations.Results) > 0, deleting: false}, nil
 }
 
-func expandTeamsList(teams []interface{}) []*matlas.ProjectTeam {
-	res := make([]*matlas.ProjectTeam, len(teams))
+func expandTeamsSet(teams *schema.Set) []*matlas.ProjectTeam {
+	res := make([]*matlas.ProjectTeam, teams.Len())
 
-	for i, value := range teams {
+	for i, value := range teams.List() {
 		v := value.(map[string]interface{})
 		res[i] = &matlas.ProjectTeam{
 			TeamID:    v["team_id"].(string),
@@ -494,10 +633,10 @@ func expandTeamsList(teams []interface{}) []*matlas.ProjectTeam {
 	return res
 }
 
-func expandAPIKeysList(apiKeys []interface{}) []*apiKey {
-	res := make([]*apiKey, len(apiKeys))
+func expandAPIKeysSet(apiKeys *schema.Set) []*apiKey {
+	res := make([]*apiKey, apiKeys.Len())
 
-	for i, value := range apiKeys {
+	for i, value := range apiKeys.List() {
 		v := value.(map[string]interface{})
 		res[i] = &apiKey{
 			id:    v["api_key_id"].(string),
@@ -535,58 +674,155 @@ func flattenAPIKeys(keys []*apiKey) []map[string]interface{} {
 	return res
 }
 
-func getStateTeams(d *schema.ResourceData) (newTeams, changedTeams, removedTeams []interface{}) {
-	currentTeams, changes := d.GetChange("teams")
+// syncProjectTeams reconciles the `teams` block against Atlas, keyed by team_id, so a team whose
+// role_names changed is sent as a single UpdateTeamRoles call instead of a remove-then-add pair.
+// It tries every add/remove/update regardless of earlier failures and returns them as one
+// consolidated diag.Diagnostics.
+func syncProjectTeams(ctx context.Context, conn *matlas.Client, projectID string, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	rTeams := currentTeams.(*schema.Set).Difference(changes.(*schema.Set))
-	nTeams := changes.(*schema.Set).Difference(currentTeams.(*schema.Set))
-	changedTeams = make([]interface{}, 0)
+	oldTeams, newTeamsRaw := d.GetChange("teams")
+	newTeams, updatedTeams, removedTeamIDs := classifyTeams(oldTeams.(*schema.Set), newTeamsRaw.(*schema.Set))
 
-	for _, changed := range nTeams.List() {
-		for _, removed := range rTeams.List() {
-			if changed.(map[string]interface{})["team_id"] == removed.(map[string]interface{})["team_id"] {
-				rTeams.Remove(removed)
-			}
+	if len(newTeams) > 0 {
+		if _, _, err := conn.Projects.AddTeamsToProject(ctx, projectID, newTeams); err != nil {
+			diags = append(diags, projectSyncDiag("adding teams to", projectID, err)...)
 		}
+	}
 
-		for _, current := range currentTeams.(*schema.Set).List() {
-			if changed.(map[string]interface{})["team_id"] == current.(map[string]interface{})["team_id"] {
-				changedTeams = append(changedTeams, changed.(map[string]interface{}))
-				nTeams.Remove(changed)
-			}
+	for _, teamID := range removedTeamIDs {
+		if _, err := conn.Teams.RemoveTeamFromProject(ctx, projectID, teamID); err != nil {
+			diags = append(diags, projectSyncDiag(fmt.Sprintf("removing team(%s) from", teamID), projectID, err)...)
 		}
 	}
 
-	newTeams = nTeams.List()
-	removedTeams = rTeams.List()
+	for _, team := range updatedTeams {
+		_, _, err := conn.Teams.UpdateTeamRoles(ctx, projectID, team.TeamID, &matlas.TeamUpdateRoles{RoleNames: team.RoleNames})
+		if err != nil {
+			diags = append(diags, projectSyncDiag(fmt.Sprintf("updating role names for team(%s) in", team.TeamID), projectID, err)...)
+		}
+	}
 
-	return
+	return diags
 }
 
-func getStateAPIKeys(d *schema.ResourceData) (newAPIKeys, changedAPIKeys, removedAPIKeys []interface{}) {
-	currentAPIKeys, changes := d.GetChange("api_keys")
+// syncProjectAPIKeys is the api_keys counterpart of syncProjectTeams, keyed by api_key_id.
+func syncProjectAPIKeys(ctx context.Context, conn *matlas.Client, projectID string, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	rAPIKeys := currentAPIKeys.(*schema.Set).Difference(changes.(*schema.Set))
-	nAPIKeys := changes.(*schema.Set).Difference(currentAPIKeys.(*schema.Set))
-	changedAPIKeys = make([]interface{}, 0)
+	oldKeys, newKeysRaw := d.GetChange("api_keys")
+	newKeys, updatedKeys, removedKeyIDs := classifyAPIKeys(oldKeys.(*schema.Set), newKeysRaw.(*schema.Set))
 
-	for _, changed := range nAPIKeys.List() {
-		for _, removed := range rAPIKeys.List() {
-			if changed.(map[string]interface{})["api_key_id"] == removed.(map[string]interface{})["api_key_id"] {
-				rAPIKeys.Remove(removed)
-			}
+	for _, key := range newKeys {
+		if _, err := conn.ProjectAPIKeys.Assign(ctx, projectID, key.id, &matlas.AssignAPIKey{Roles: key.roles}); err != nil {
+			diags = append(diags, projectSyncDiag(fmt.Sprintf("assigning api_key(%s) into", key.id), projectID, err)...)
 		}
+	}
 
-		for _, current := range currentAPIKeys.(*schema.Set).List() {
-			if changed.(map[string]interface{})["api_key_id"] == current.(map[string]interface{})["api_key_id"] {
-				changedAPIKeys = append(changedAPIKeys, changed.(map[string]interface{}))
-				nAPIKeys.Remove(changed)
-			}
+	for _, keyID := range removedKeyIDs {
+		if _, err := conn.ProjectAPIKeys.Unassign(ctx, projectID, keyID); err != nil {
+			diags = append(diags, projectSyncDiag(fmt.Sprintf("removing api_key(%s) from", keyID), projectID, err)...)
+		}
+	}
+
+	for _, key := range updatedKeys {
+		if _, err := conn.ProjectAPIKeys.Assign(ctx, projectID, key.id, &matlas.AssignAPIKey{Roles: key.roles}); err != nil {
+			diags = append(diags, projectSyncDiag(fmt.Sprintf("updating role names for api_key(%s) in", key.id), projectID, err)...)
+		}
+	}
+
+	return diags
+}
+
+// projectSyncDiag turns a team/api_key sync failure into a diagnostic, except Atlas's
+// USER_UNAUTHORIZED, which this resource has always tolerated since callers without org-level
+// permissions still need to be able to manage the rest of the project.
+func projectSyncDiag(action, projectID string, err error) diag.Diagnostics {
+	var target *matlas.ErrorResponse
+	if errors.As(err, &target) && target.ErrorCode == "USER_UNAUTHORIZED" {
+		log.Printf("[WARN] error %s project(%s): %s", action, projectID, err)
+		return nil
+	}
+
+	return diag.Errorf("error %s project(%s): %s", action, projectID, err)
+}
+
+// classifyTeams diffs the old and new `teams` sets by team_id, so a team whose role_names changed
+// classifies as an update rather than a remove-then-add pair.
+func classifyTeams(oldSet, newSet *schema.Set) (newTeams, updatedTeams []*matlas.ProjectTeam, removedTeamIDs []string) {
+	oldByID := teamsByID(oldSet)
+	newByID := teamsByID(newSet)
+
+	for id, team := range newByID {
+		old, existed := oldByID[id]
+		roleNames := expandStringList(team["role_names"].(*schema.Set).List())
+
+		switch {
+		case !existed:
+			newTeams = append(newTeams, &matlas.ProjectTeam{TeamID: id, RoleNames: roleNames})
+		case !stringSetEqual(old["role_names"].(*schema.Set), team["role_names"].(*schema.Set)):
+			updatedTeams = append(updatedTeams, &matlas.ProjectTeam{TeamID: id, RoleNames: roleNames})
+		}
+	}
+
+	for id := range oldByID {
+		if _, stillPresent := newByID[id]; !stillPresent {
+			removedTeamIDs = append(removedTeamIDs, id)
+		}
+	}
+
+	return
+}
+
+// classifyAPIKeys is the api_keys counterpart of classifyTeams, keyed by api_key_id.
+func classifyAPIKeys(oldSet, newSet *schema.Set) (newKeys, updatedKeys []*apiKey, removedKeyIDs []string) {
+	oldByID := apiKeysByID(oldSet)
+	newByID := apiKeysByID(newSet)
+
+	for id, key := range newByID {
+		old, existed := oldByID[id]
+		roles := expandStringList(key["role_names"].(*schema.Set).List())
+
+		switch {
+		case !existed:
+			newKeys = append(newKeys, &apiKey{id: id, roles: roles})
+		case !stringSetEqual(old["role_names"].(*schema.Set), key["role_names"].(*schema.Set)):
+			updatedKeys = append(updatedKeys, &apiKey{id: id, roles: roles})
 		}
 	}
 
-	newAPIKeys = nAPIKeys.List()
-	removedAPIKeys = rAPIKeys.List()
+	for id := range oldByID {
+		if _, stillPresent := newByID[id]; !stillPresent {
+			removedKeyIDs = append(removedKeyIDs, id)
+		}
+	}
 
 	return
 }
+
+func teamsByID(teams *schema.Set) map[string]map[string]interface{} {
+	res := make(map[string]map[string]interface{}, teams.Len())
+
+	for _, v := range teams.List() {
+		team := v.(map[string]interface{})
+		res[team["team_id"].(string)] = team
+	}
+
+	return res
+}
+
+func apiKeysByID(apiKeys *schema.Set) map[string]map[string]interface{} {
+	res := make(map[string]map[string]interface{}, apiKeys.Len())
+
+	for _, v := range apiKeys.List() {
+		key := v.(map[string]interface{})
+		res[key["api_key_id"].(string)] = key
+	}
+
+	return res
+}
+
+// stringSetEqual reports whether two TypeSet-of-string values contain the same elements.
+func stringSetEqual(a, b *schema.Set) bool {
+	return a.Difference(b).Len() == 0 && b.Difference(a).Len() == 0
+}
@@ -0,0 +1,111 @@
+package mongodbatlas
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAtlastProjectDependentsNames(t *testing.T) {
+	tests := map[string]struct {
+		statuses []dependentStatus
+		want     []string
+	}{
+		"no dependents present": {
+			statuses: []dependentStatus{{name: "advanced_clusters", present: false}},
+			want:     nil,
+		},
+		"present dependent is named regardless of deleting state": {
+			statuses: []dependentStatus{
+				{name: "advanced_clusters", present: true, deleting: false},
+				{name: "online_archives", present: true, deleting: true},
+				{name: "serverless_instances", present: false},
+			},
+			want: []string{"advanced_clusters", "online_archives"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := &AtlastProjectDependents{Statuses: tc.statuses}
+			assertStringSliceEqual(t, "names", d.names(), tc.want)
+		})
+	}
+}
+
+func TestAtlastProjectDependentsReadyToDelete(t *testing.T) {
+	tests := map[string]struct {
+		statuses []dependentStatus
+		want     bool
+	}{
+		"nothing present is never ready to delete": {
+			statuses: []dependentStatus{{name: "advanced_clusters", present: false}},
+			want:     false,
+		},
+		"a present, non-deleting dependent blocks readiness": {
+			statuses: []dependentStatus{{name: "advanced_clusters", present: true, deleting: false}},
+			want:     false,
+		},
+		"every present dependent deleting is ready": {
+			statuses: []dependentStatus{
+				{name: "advanced_clusters", present: true, deleting: true},
+				{name: "online_archives", present: false},
+			},
+			want: true,
+		},
+		"one non-deleting dependent blocks readiness even if others are deleting": {
+			statuses: []dependentStatus{
+				{name: "advanced_clusters", present: true, deleting: true},
+				{name: "online_archives", present: true, deleting: false},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := &AtlastProjectDependents{Statuses: tc.statuses}
+			if got := d.readyToDelete(); got != tc.want {
+				t.Fatalf("readyToDelete() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestAccProjectRSProject_withDeletionProtection is the acceptance-test counterpart of
+// TestAtlastProjectDependentsNames/TestAtlastProjectDependentsReadyToDelete above: it exercises
+// `deletion_protection` against a real Atlas project instead of canned dependentStatus values,
+// confirming destroy is rejected while a dependent cluster exists and succeeds once it's gone.
+func TestAccProjectRSProject_withDeletionProtection(t *testing.T) {
+	var (
+		resourceName = "mongodbatlas_project.test"
+		projectName  = acctest.RandomWithPrefix("test-acc")
+		orgID        = os.Getenv("MONGODB_ATLAS_ORG_ID")
+		clusterName  = acctest.RandomWithPrefix("test-acc")
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckBasic(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckMongoDBAtlasProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				// A dependent cluster still exists, so destroying this step's plan must fail.
+				Config:      testAccMongoDBAtlasProjectConfigWithDeletionProtectionAndCluster(orgID, projectName, clusterName, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("still has dependents"),
+			},
+			{
+				// With deletion_protection off and the cluster torn down, destroy must succeed.
+				Config: testAccMongoDBAtlasProjectConfigWithDeletionProtectionAndCluster(orgID, projectName, clusterName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBAtlasProjectExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
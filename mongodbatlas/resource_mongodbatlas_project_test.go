@@ -0,0 +1,181 @@
+package mongodbatlas
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	matlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+func newTeamsSet(teams ...map[string]interface{}) *schema.Set {
+	elem := resourceMongoDBAtlasProject().Schema["teams"].Elem.(*schema.Resource)
+
+	items := make([]interface{}, len(teams))
+	for i, team := range teams {
+		items[i] = team
+	}
+
+	return schema.NewSet(schema.HashResource(elem), items)
+}
+
+func newAPIKeysSet(apiKeys ...map[string]interface{}) *schema.Set {
+	elem := resourceMongoDBAtlasProject().Schema["api_keys"].Elem.(*schema.Resource)
+
+	items := make([]interface{}, len(apiKeys))
+	for i, key := range apiKeys {
+		items[i] = key
+	}
+
+	return schema.NewSet(schema.HashResource(elem), items)
+}
+
+func rolesSet(roleNames ...string) *schema.Set {
+	items := make([]interface{}, len(roleNames))
+	for i, role := range roleNames {
+		items[i] = role
+	}
+
+	return schema.NewSet(schema.HashString, items)
+}
+
+func teamRaw(teamID string, roleNames ...string) map[string]interface{} {
+	return map[string]interface{}{"team_id": teamID, "role_names": rolesSet(roleNames...)}
+}
+
+func apiKeyRaw(apiKeyID string, roleNames ...string) map[string]interface{} {
+	return map[string]interface{}{"api_key_id": apiKeyID, "role_names": rolesSet(roleNames...)}
+}
+
+func TestClassifyTeams(t *testing.T) {
+	tests := map[string]struct {
+		old, new       *schema.Set
+		wantNew        []*matlas.ProjectTeam
+		wantUpdated    []*matlas.ProjectTeam
+		wantRemovedIDs []string
+	}{
+		"new team is classified as an add": {
+			old:     newTeamsSet(),
+			new:     newTeamsSet(teamRaw("team1", "GROUP_READ_ONLY")),
+			wantNew: []*matlas.ProjectTeam{{TeamID: "team1", RoleNames: []string{"GROUP_READ_ONLY"}}},
+		},
+		"absent team is classified as a removal": {
+			old:            newTeamsSet(teamRaw("team1", "GROUP_READ_ONLY")),
+			new:            newTeamsSet(),
+			wantRemovedIDs: []string{"team1"},
+		},
+		"changed role_names classifies as an update, not a remove+add pair": {
+			old:         newTeamsSet(teamRaw("team1", "GROUP_READ_ONLY")),
+			new:         newTeamsSet(teamRaw("team1", "GROUP_DATA_ACCESS_ADMIN")),
+			wantUpdated: []*matlas.ProjectTeam{{TeamID: "team1", RoleNames: []string{"GROUP_DATA_ACCESS_ADMIN"}}},
+		},
+		"unchanged team produces no diff": {
+			old: newTeamsSet(teamRaw("team1", "GROUP_READ_ONLY")),
+			new: newTeamsSet(teamRaw("team1", "GROUP_READ_ONLY")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			newTeams, updatedTeams, removedTeamIDs := classifyTeams(tc.old, tc.new)
+
+			assertProjectTeamsEqual(t, "new", newTeams, tc.wantNew)
+			assertProjectTeamsEqual(t, "updated", updatedTeams, tc.wantUpdated)
+			assertStringSliceEqual(t, "removed", removedTeamIDs, tc.wantRemovedIDs)
+		})
+	}
+}
+
+func TestClassifyAPIKeys(t *testing.T) {
+	tests := map[string]struct {
+		old, new       *schema.Set
+		wantNew        []*apiKey
+		wantUpdated    []*apiKey
+		wantRemovedIDs []string
+	}{
+		"new api key is classified as an assign": {
+			old:     newAPIKeysSet(),
+			new:     newAPIKeysSet(apiKeyRaw("key1", "GROUP_READ_ONLY")),
+			wantNew: []*apiKey{{id: "key1", roles: []string{"GROUP_READ_ONLY"}}},
+		},
+		"absent api key is classified as a removal": {
+			old:            newAPIKeysSet(apiKeyRaw("key1", "GROUP_READ_ONLY")),
+			new:            newAPIKeysSet(),
+			wantRemovedIDs: []string{"key1"},
+		},
+		"changed role_names classifies as an update": {
+			old:         newAPIKeysSet(apiKeyRaw("key1", "GROUP_READ_ONLY")),
+			new:         newAPIKeysSet(apiKeyRaw("key1", "GROUP_DATA_ACCESS_ADMIN")),
+			wantUpdated: []*apiKey{{id: "key1", roles: []string{"GROUP_DATA_ACCESS_ADMIN"}}},
+		},
+		"unchanged api key produces no diff": {
+			old: newAPIKeysSet(apiKeyRaw("key1", "GROUP_READ_ONLY")),
+			new: newAPIKeysSet(apiKeyRaw("key1", "GROUP_READ_ONLY")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			newKeys, updatedKeys, removedKeyIDs := classifyAPIKeys(tc.old, tc.new)
+
+			assertAPIKeysEqual(t, "new", newKeys, tc.wantNew)
+			assertAPIKeysEqual(t, "updated", updatedKeys, tc.wantUpdated)
+			assertStringSliceEqual(t, "removed", removedKeyIDs, tc.wantRemovedIDs)
+		})
+	}
+}
+
+func assertProjectTeamsEqual(t *testing.T, label string, got, want []*matlas.ProjectTeam) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("%s teams: got %d, want %d (%+v vs %+v)", label, len(got), len(want), got, want)
+	}
+
+	sort.Slice(got, func(i, j int) bool { return got[i].TeamID < got[j].TeamID })
+	sort.Slice(want, func(i, j int) bool { return want[i].TeamID < want[j].TeamID })
+
+	for i := range got {
+		if got[i].TeamID != want[i].TeamID {
+			t.Errorf("%s teams[%d].TeamID = %q, want %q", label, i, got[i].TeamID, want[i].TeamID)
+		}
+
+		assertStringSliceEqual(t, label+" role_names", got[i].RoleNames, want[i].RoleNames)
+	}
+}
+
+func assertAPIKeysEqual(t *testing.T, label string, got, want []*apiKey) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("%s api_keys: got %d, want %d (%+v vs %+v)", label, len(got), len(want), got, want)
+	}
+
+	sort.Slice(got, func(i, j int) bool { return got[i].id < got[j].id })
+	sort.Slice(want, func(i, j int) bool { return want[i].id < want[j].id })
+
+	for i := range got {
+		if got[i].id != want[i].id {
+			t.Errorf("%s api_keys[%d].id = %q, want %q", label, i, got[i].id, want[i].id)
+		}
+
+		assertStringSliceEqual(t, label+" role_names", got[i].roles, want[i].roles)
+	}
+}
+
+func assertStringSliceEqual(t *testing.T, label string, got, want []string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %v, want %v", label, got, want)
+	}
+
+	sort.Strings(got)
+	sort.Strings(want)
+
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("%s: got %v, want %v", label, got, want)
+		}
+	}
+}